@@ -0,0 +1,238 @@
+package merklederkle
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Serialization formats, modeled on OpenZeppelin's StandardMerkleTree
+// dump()/load() so a tree built once can be shipped to a frontend or stored
+// on disk and reopened without recomputing any hashes.
+
+type rawTreeDump struct {
+	Format string  `json:"format"`
+	Tree   []Bytes `json:"tree"`
+	Hash   string  `json:"hash"`
+}
+
+// DumpTree serializes the raw output of MakeMerkleTree.
+func DumpTree(tree []Bytes) ([]byte, error) {
+	return json.Marshal(rawTreeDump{Format: "raw-v1", Tree: tree, Hash: "keccak256"})
+}
+
+// LoadTree re-hydrates a tree previously produced by DumpTree, rejecting it
+// if it has been tampered with.
+func LoadTree(data []byte) ([]Bytes, error) {
+	var dump rawTreeDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return nil, err
+	}
+	if !isValidMerkleTree(dump.Tree) {
+		return nil, throwError("Merkle tree is not valid")
+	}
+	return dump.Tree, nil
+}
+
+type standardTreeDump struct {
+	Format       string          `json:"format"`
+	Tree         []Bytes         `json:"tree"`
+	Values       []dumpedStdLeaf `json:"values"`
+	LeafEncoding []string        `json:"leafEncoding"`
+	Hash         string          `json:"hash"`
+}
+
+type dumpedStdLeaf struct {
+	Value     json.RawMessage `json:"value"`
+	TreeIndex int             `json:"treeIndex"`
+}
+
+// Dump serializes the tree to OpenZeppelin's "standard-v1" JSON format.
+func (t *StandardTree) Dump() ([]byte, error) {
+	values := make([]dumpedStdLeaf, len(t.values))
+	for i, v := range t.values {
+		raw, err := encodeLeafValue(v.Value, t.leafEncodings)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = dumpedStdLeaf{Value: raw, TreeIndex: v.TreeIndex}
+	}
+
+	return json.Marshal(standardTreeDump{
+		Format:       "standard-v1",
+		Tree:         t.tree,
+		Values:       values,
+		LeafEncoding: t.leafEncodings,
+		Hash:         "keccak256",
+	})
+}
+
+// LoadStandardTree re-hydrates a tree previously produced by Dump, rejecting
+// it if it has been tampered with.
+func LoadStandardTree(data []byte) (*StandardTree, error) {
+	var dump standardTreeDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return nil, err
+	}
+	if dump.Format != "standard-v1" {
+		return nil, throwError("Unrecognized tree dump format: " + dump.Format)
+	}
+	if !isValidMerkleTree(dump.Tree) {
+		return nil, throwError("Merkle tree is not valid")
+	}
+
+	values := make([]StandardLeaf, len(dump.Values))
+	for i, dv := range dump.Values {
+		value, err := decodeLeafValue(dv.Value, dump.LeafEncoding)
+		if err != nil {
+			return nil, err
+		}
+		leaf, err := standardLeafHash(dump.LeafEncoding, value)
+		if err != nil {
+			return nil, err
+		}
+		if dv.TreeIndex < 0 || dv.TreeIndex >= len(dump.Tree) || !equalsBytes(dump.Tree[dv.TreeIndex], leaf) {
+			return nil, throwError("Merkle tree does not contain the expected leaf value")
+		}
+		values[i] = StandardLeaf{Value: value, TreeIndex: dv.TreeIndex, leaf: leaf}
+	}
+
+	return &StandardTree{tree: dump.Tree, values: values, leafEncodings: dump.LeafEncoding}, nil
+}
+
+func encodeLeafValue(value []interface{}, leafEncodings []string) (json.RawMessage, error) {
+	if len(value) != len(leafEncodings) {
+		return nil, throwError("Value and leafEncodings length mismatch")
+	}
+
+	parts := make([]json.RawMessage, len(leafEncodings))
+	for i, encoding := range leafEncodings {
+		encoded, err := encodeEncodedValue(value[i], encoding)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = encoded
+	}
+	return json.Marshal(parts)
+}
+
+func encodeEncodedValue(value interface{}, encoding string) (json.RawMessage, error) {
+	switch {
+	case encoding == "bytes":
+		b, ok := value.([]byte)
+		if !ok {
+			return nil, throwError("Unsupported value for leaf encoding: " + encoding)
+		}
+		return json.Marshal(hexutil.Encode(b))
+	case strings.HasPrefix(encoding, "bytes"):
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Array || v.Type().Elem().Kind() != reflect.Uint8 {
+			return nil, throwError("Unsupported value for leaf encoding: " + encoding)
+		}
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return json.Marshal(hexutil.Encode(b))
+	case strings.HasPrefix(encoding, "uint") || strings.HasPrefix(encoding, "int"):
+		// OpenZeppelin's standard-v1 format emits uint/int values as decimal
+		// strings rather than bare JSON numbers, so a uint256 doesn't lose
+		// precision in a frontend's JSON.parse.
+		n, ok := value.(*big.Int)
+		if !ok {
+			return nil, throwError("Unsupported value for leaf encoding: " + encoding)
+		}
+		return json.Marshal(n.String())
+	default:
+		return json.Marshal(value)
+	}
+}
+
+func decodeLeafValue(raw json.RawMessage, leafEncodings []string) ([]interface{}, error) {
+	var parts []json.RawMessage
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return nil, err
+	}
+	if len(parts) != len(leafEncodings) {
+		return nil, throwError("Value and leafEncodings length mismatch")
+	}
+
+	value := make([]interface{}, len(leafEncodings))
+	for i, encoding := range leafEncodings {
+		decoded, err := decodeEncodedValue(parts[i], encoding)
+		if err != nil {
+			return nil, err
+		}
+		value[i] = decoded
+	}
+	return value, nil
+}
+
+func decodeEncodedValue(raw json.RawMessage, encoding string) (interface{}, error) {
+	switch {
+	case encoding == "address":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return common.HexToAddress(s), nil
+	case encoding == "bool":
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case encoding == "string":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case strings.HasPrefix(encoding, "uint") || strings.HasPrefix(encoding, "int"):
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, throwError("Invalid integer value for leaf encoding " + encoding + ": " + s)
+		}
+		return n, nil
+	case strings.HasPrefix(encoding, "bytes"):
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		decoded, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if encoding == "bytes" {
+			return decoded, nil
+		}
+		size, err := bytesNSize(encoding)
+		if err != nil {
+			return nil, err
+		}
+		if len(decoded) != size {
+			return nil, throwError("Unexpected length for leaf encoding " + encoding)
+		}
+		arr := reflect.New(reflect.ArrayOf(size, reflect.TypeOf(byte(0)))).Elem()
+		reflect.Copy(arr, reflect.ValueOf(decoded))
+		return arr.Interface(), nil
+	default:
+		return nil, throwError("Unsupported leaf encoding: " + encoding)
+	}
+}
+
+// bytesNSize parses the N out of a Solidity "bytesN" encoding name.
+func bytesNSize(encoding string) (int, error) {
+	size, err := strconv.Atoi(strings.TrimPrefix(encoding, "bytes"))
+	if err != nil || size <= 0 || size > 32 {
+		return 0, throwError("Unsupported leaf encoding: " + encoding)
+	}
+	return size, nil
+}
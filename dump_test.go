@@ -0,0 +1,135 @@
+package merklederkle
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestStandardTree_DumpAndLoad(t *testing.T) {
+	values := [][]interface{}{
+		{common.HexToAddress("0x1111111111111111111111111111111111111111"), big.NewInt(5)},
+		{common.HexToAddress("0x2222222222222222222222222222222222222222"), big.NewInt(10)},
+	}
+	encodings := []string{"address", "uint256"}
+
+	tree, err := NewStandardTree(values, encodings)
+	if err != nil {
+		t.Fatalf("NewStandardTree returned error: %v", err)
+	}
+
+	data, err := tree.Dump()
+	if err != nil {
+		t.Fatalf("Dump returned error: %v", err)
+	}
+
+	loaded, err := LoadStandardTree(data)
+	if err != nil {
+		t.Fatalf("LoadStandardTree returned error: %v", err)
+	}
+
+	if !equalsBytes(loaded.Root(), tree.Root()) {
+		t.Error("loaded tree root does not match original")
+	}
+
+	proof, err := loaded.GetProof(values[0])
+	if err != nil {
+		t.Fatalf("GetProof on loaded tree returned error: %v", err)
+	}
+	if len(proof) == 0 && len(values) > 1 {
+		t.Error("expected a non-empty proof")
+	}
+}
+
+func TestStandardTree_DumpAndLoad_BytesEncodings(t *testing.T) {
+	var b32a, b32b [32]byte
+	b32a[0], b32b[0] = 0xaa, 0xbb
+	var b4 [4]byte
+	copy(b4[:], []byte{0xde, 0xad, 0xbe, 0xef})
+
+	values := [][]interface{}{
+		{b32a, []byte{0x01, 0x02, 0x03}, b4},
+		{b32b, []byte{0x04, 0x05}, [4]byte{0x01, 0x02, 0x03, 0x04}},
+	}
+	encodings := []string{"bytes32", "bytes", "bytes4"}
+
+	tree, err := NewStandardTree(values, encodings)
+	if err != nil {
+		t.Fatalf("NewStandardTree returned error: %v", err)
+	}
+
+	data, err := tree.Dump()
+	if err != nil {
+		t.Fatalf("Dump returned error: %v", err)
+	}
+
+	loaded, err := LoadStandardTree(data)
+	if err != nil {
+		t.Fatalf("LoadStandardTree returned error: %v", err)
+	}
+	if !equalsBytes(loaded.Root(), tree.Root()) {
+		t.Error("loaded tree root does not match original")
+	}
+
+	if _, err := loaded.GetProof(values[1]); err != nil {
+		t.Fatalf("GetProof on loaded tree returned error: %v", err)
+	}
+}
+
+func TestStandardTree_Dump_IntegersAreDecimalStrings(t *testing.T) {
+	// A uint256 this large would lose precision if parsed as a bare JSON
+	// number by a JS frontend (doubles only carry 53 bits of precision).
+	big256, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	values := [][]interface{}{
+		{common.HexToAddress("0x1111111111111111111111111111111111111111"), big256},
+	}
+	encodings := []string{"address", "uint256"}
+
+	tree, err := NewStandardTree(values, encodings)
+	if err != nil {
+		t.Fatalf("NewStandardTree returned error: %v", err)
+	}
+
+	data, err := tree.Dump()
+	if err != nil {
+		t.Fatalf("Dump returned error: %v", err)
+	}
+	if !bytes.Contains(data, []byte(`"`+big256.String()+`"`)) {
+		t.Errorf("expected dump to encode the uint256 as a quoted decimal string, got: %s", data)
+	}
+
+	loaded, err := LoadStandardTree(data)
+	if err != nil {
+		t.Fatalf("LoadStandardTree returned error: %v", err)
+	}
+	if !equalsBytes(loaded.Root(), tree.Root()) {
+		t.Error("loaded tree root does not match original")
+	}
+}
+
+func TestLoadTree_RejectsTamperedDump(t *testing.T) {
+	tree := GenerateMerkleTree([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+
+	data, err := DumpTree(tree)
+	if err != nil {
+		t.Fatalf("DumpTree returned error: %v", err)
+	}
+
+	if _, err := LoadTree(data); err != nil {
+		t.Fatalf("LoadTree rejected a valid dump: %v", err)
+	}
+
+	tampered := make([]Bytes, len(tree))
+	copy(tampered, tree)
+	tampered[0] = Bytes(make([]byte, 32))
+	data, err = DumpTree(tampered)
+	if err != nil {
+		t.Fatalf("DumpTree returned error: %v", err)
+	}
+
+	if _, err := LoadTree(data); err == nil {
+		t.Error("expected LoadTree to reject a tampered dump")
+	}
+}
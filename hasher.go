@@ -0,0 +1,46 @@
+package merklederkle
+
+import (
+	"crypto/sha256"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Hasher abstracts the hash function used to combine tree nodes. The
+// package's original API hardwires Keccak-256 for EVM compatibility; Tree
+// lets callers doing non-EVM or zk-friendly commitments plug in their own.
+type Hasher interface {
+	Hash(data ...[]byte) []byte
+	Size() int
+}
+
+// KeccakHasher is the default Hasher and preserves the library's original
+// Keccak-256 behavior.
+type KeccakHasher struct{}
+
+func (KeccakHasher) Hash(data ...[]byte) []byte { return crypto.Keccak256(data...) }
+func (KeccakHasher) Size() int                  { return 32 }
+
+// Sha256Hasher hashes nodes with SHA-256, for RFC 6962 / Cosmos-style trees.
+type Sha256Hasher struct{}
+
+func (Sha256Hasher) Hash(data ...[]byte) []byte {
+	h := sha256.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func (Sha256Hasher) Size() int { return sha256.Size }
+
+// PoseidonHasher is a hook for zk-friendly commitments: it delegates to
+// caller-supplied Hash/Size implementations, since this package does not
+// depend on any particular Poseidon implementation (gnark, iden3, ...).
+type PoseidonHasher struct {
+	HashFn func(data ...[]byte) []byte
+	SizeFn func() int
+}
+
+func (p PoseidonHasher) Hash(data ...[]byte) []byte { return p.HashFn(data...) }
+func (p PoseidonHasher) Size() int                  { return p.SizeFn() }
@@ -0,0 +1,129 @@
+package merklederkle
+
+import (
+	"bytes"
+	"sort"
+)
+
+// IncrementalTreeMode selects how IncrementalTree orders leaves.
+type IncrementalTreeMode int
+
+const (
+	// InsertionOrderMode pairs leaves in push order (RFC 6962 / cached-tree
+	// semantics); Root() is available after every Push.
+	InsertionOrderMode IncrementalTreeMode = iota
+	// SortedMode matches MakeMerkleTree's semantics: leaves are buffered and
+	// only sorted and paired once Finalize is called.
+	SortedMode
+)
+
+// IncrementalTree accumulates leaves without rehashing everything on every
+// addition, unlike MakeMerkleTree's full 2*len(leaves)-1 rebuild. In
+// InsertionOrderMode it keeps a "frontier" - one pending completed-subtree
+// root per level, as in RFC 6962's cached Merkle accumulator - so Push is
+// O(log n) amortized and Root() never rehashes a completed subtree.
+//
+// Root()'s frontier-folded value and Snapshot()'s tree[0] are only
+// guaranteed to be byte-identical when the leaf count is a power of two:
+// MakeMerkleTree/GetProof use a fixed array packing whose shape depends on
+// the final leaf count, so growing past a power of two reshapes earlier
+// pairings in a full rebuild. Callers that need a GetProof/GetMultiProof-
+// compatible tree matching the current Root() must call Snapshot(), which
+// always rebuilds from scratch.
+type IncrementalTree struct {
+	Hasher Hasher
+	Mode   IncrementalTreeMode
+
+	leaves   []Bytes
+	frontier []Bytes
+}
+
+// NewIncrementalTree creates an empty IncrementalTree. A nil hasher defaults
+// to KeccakHasher.
+func NewIncrementalTree(mode IncrementalTreeMode, hasher Hasher) *IncrementalTree {
+	if hasher == nil {
+		hasher = KeccakHasher{}
+	}
+	return &IncrementalTree{Hasher: hasher, Mode: mode}
+}
+
+// Push appends leaf to the tree. In InsertionOrderMode it also folds leaf
+// into the frontier in O(log n) amortized; in SortedMode it only buffers
+// the leaf until Finalize is called.
+func (it *IncrementalTree) Push(leaf Bytes) error {
+	if err := checkValidMerkleNodeWith(it.Hasher, leaf); err != nil {
+		return err
+	}
+	it.leaves = append(it.leaves, leaf)
+
+	if it.Mode == SortedMode {
+		return nil
+	}
+
+	node := leaf
+	for level := 0; ; level++ {
+		if level == len(it.frontier) {
+			it.frontier = append(it.frontier, nil)
+		}
+		if it.frontier[level] == nil {
+			it.frontier[level] = node
+			return nil
+		}
+		node = hashPairWith(it.Hasher, it.frontier[level], node)
+		it.frontier[level] = nil
+	}
+}
+
+// Root returns the tree's current root by folding the frontier's pending
+// subtree roots together, without rehashing any completed subtree. Only
+// available in InsertionOrderMode; SortedMode callers must use Finalize.
+func (it *IncrementalTree) Root() (Bytes, error) {
+	if len(it.leaves) == 0 {
+		return nil, throwError("Expected non-zero number of leaves")
+	}
+	if it.Mode == SortedMode {
+		return nil, throwError("Root is not available in SortedMode until Finalize is called")
+	}
+
+	var root Bytes
+	for _, node := range it.frontier {
+		if node == nil {
+			continue
+		}
+		if root == nil {
+			root = node
+		} else {
+			root = hashPairWith(it.Hasher, node, root)
+		}
+	}
+	return root, nil
+}
+
+// Snapshot materializes a full tree from every leaf pushed so far, using the
+// same array packing as MakeMerkleTree, so it is compatible with the
+// existing GetProof/GetMultiProof helpers. In SortedMode this sorts leaves
+// first, matching MakeMerkleTree's semantics.
+func (it *IncrementalTree) Snapshot() ([]Bytes, error) {
+	if len(it.leaves) == 0 {
+		return nil, throwError("Expected non-zero number of leaves")
+	}
+
+	leaves := make([]Bytes, len(it.leaves))
+	copy(leaves, it.leaves)
+	if it.Mode == SortedMode {
+		sort.Slice(leaves, func(i, j int) bool { return bytes.Compare(leaves[i], leaves[j]) < 0 })
+	}
+	return buildOrderedTree(leaves, it.Hasher), nil
+}
+
+// Finalize rebuilds the tree from every pushed leaf via Snapshot (sorting
+// them first in SortedMode; InsertionOrderMode keeps push order), returning
+// its root alongside the full tree. It is the SortedMode counterpart to
+// InsertionOrderMode's incremental Root().
+func (it *IncrementalTree) Finalize() (Bytes, []Bytes, error) {
+	tree, err := it.Snapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	return tree[0], tree, nil
+}
@@ -0,0 +1,66 @@
+package merklederkle
+
+import "testing"
+
+func TestIncrementalTree_InsertionOrderPowerOfTwo(t *testing.T) {
+	leaves := []Bytes{
+		make(Bytes, 32), make(Bytes, 32), make(Bytes, 32), make(Bytes, 32),
+	}
+	for i := range leaves {
+		leaves[i][0] = byte(i + 1)
+	}
+
+	it := NewIncrementalTree(InsertionOrderMode, nil)
+	for _, leaf := range leaves {
+		if err := it.Push(leaf); err != nil {
+			t.Fatalf("Push returned error: %v", err)
+		}
+	}
+
+	root, err := it.Root()
+	if err != nil {
+		t.Fatalf("Root returned error: %v", err)
+	}
+
+	tree, err := it.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot returned error: %v", err)
+	}
+
+	if !equalsBytes(root, tree[0]) {
+		t.Error("expected frontier Root() to match Snapshot() root for a power-of-two leaf count")
+	}
+
+	proof, err := GetProof(tree, len(tree)-1)
+	if err != nil {
+		t.Fatalf("GetProof returned error: %v", err)
+	}
+	reconstructed, err := ProcessProof(tree[len(tree)-1], proof)
+	if err != nil {
+		t.Fatalf("ProcessProof returned error: %v", err)
+	}
+	if !equalsBytes(reconstructed, tree[0]) {
+		t.Error("proof from snapshot did not reconstruct the root")
+	}
+}
+
+func TestIncrementalTree_SortedModeDefersRoot(t *testing.T) {
+	it := NewIncrementalTree(SortedMode, nil)
+	leaf := make(Bytes, 32)
+	leaf[0] = 0x01
+	if err := it.Push(leaf); err != nil {
+		t.Fatalf("Push returned error: %v", err)
+	}
+
+	if _, err := it.Root(); err == nil {
+		t.Error("expected Root to fail before Finalize in SortedMode")
+	}
+
+	root, tree, err := it.Finalize()
+	if err != nil {
+		t.Fatalf("Finalize returned error: %v", err)
+	}
+	if !equalsBytes(root, tree[0]) {
+		t.Error("expected Finalize's returned root to match tree[0]")
+	}
+}
@@ -11,12 +11,13 @@ import (
 	"math"
 	"math/big"
 	"sort"
+	"strings"
 )
 
 type Bytes []byte
 
 func (b *Bytes) MarshalJSON() ([]byte, error) {
-	return json.Marshal(hex.EncodeToString(*b))
+	return json.Marshal("0x" + hex.EncodeToString(*b))
 }
 
 func (b *Bytes) UnmarshalJSON(data []byte) error {
@@ -24,7 +25,7 @@ func (b *Bytes) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
-	decoded, err := hex.DecodeString(s)
+	decoded, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
 	if err != nil {
 		return err
 	}
@@ -107,8 +108,8 @@ func isLeafNode(tree []Bytes, i int) bool {
 	return isTreeNode(tree, i) && !isInternalNode(tree, i)
 }
 
-func isValidMerkleNode(node Bytes) bool {
-	return len(node) == 32
+func isValidMerkleNode(node Bytes, size int) bool {
+	return len(node) == size
 }
 
 func checkLeafNode(tree []Bytes, i int) error {
@@ -119,7 +120,7 @@ func checkLeafNode(tree []Bytes, i int) error {
 }
 
 func checkValidMerkleNode(node Bytes) error {
-	if !isValidMerkleNode(node) {
+	if !isValidMerkleNode(node, 32) {
 		return throwError("Merkle tree nodes must be Bytes of length 32")
 	}
 	return nil
@@ -185,14 +186,16 @@ type MultiProof struct {
 	ProofFlags []bool
 }
 
-func GetMultiProof(tree []Bytes, indices []int) MultiProof {
+func GetMultiProof(tree []Bytes, indices []int) (MultiProof, error) {
 	for _, i := range indices {
-		checkLeafNode(tree, i)
+		if err := checkLeafNode(tree, i); err != nil {
+			return MultiProof{}, err
+		}
 	}
 	indices = sortIndicesDesc(indices)
 
 	if hasDuplicateIndex(indices) {
-		panic(errors.New("Cannot prove duplicated index"))
+		return MultiProof{}, throwError("Cannot prove duplicated index")
 	}
 
 	stack := make([]int, len(indices))
@@ -202,21 +205,13 @@ func GetMultiProof(tree []Bytes, indices []int) MultiProof {
 
 	for len(stack) > 0 && stack[0] > 0 {
 		j := stack[0]
-		if len(stack) > 1 {
-			stack = stack[1:] // consume from the stack
-		} else {
-			stack = make([]int, 0)
-		}
+		stack = stack[1:]
 		s := siblingIndex(j)
 		p, _ := parentIndex(j)
 
 		if len(stack) > 0 && s == stack[0] {
 			proofFlags = append(proofFlags, true)
-			if len(stack) > 1 {
-				stack = stack[1:] // consume from the stack
-			} else {
-				stack = make([]int, 0)
-			}
+			stack = stack[1:]
 		} else {
 			proofFlags = append(proofFlags, false)
 			proof = append(proof, tree[s])
@@ -232,23 +227,31 @@ func GetMultiProof(tree []Bytes, indices []int) MultiProof {
 		Leaves:     getIndicesValues(tree, indices),
 		Proof:      proof,
 		ProofFlags: proofFlags,
-	}
+	}, nil
 }
 
-func ProcessMultiProof(multiproof MultiProof) Bytes {
+// ProcessMultiProof recombines a multiproof into its root. It returns an
+// error, rather than panicking, on any malformed input - including the
+// adversarial ProofFlags/proof-length combinations an untrusted RPC
+// endpoint could hand back.
+func ProcessMultiProof(multiproof MultiProof) (Bytes, error) {
 	for _, l := range multiproof.Leaves {
-		checkValidMerkleNode(l)
+		if err := checkValidMerkleNode(l); err != nil {
+			return nil, err
+		}
 	}
 	for _, p := range multiproof.Proof {
-		checkValidMerkleNode(p)
+		if err := checkValidMerkleNode(p); err != nil {
+			return nil, err
+		}
 	}
 
 	if len(multiproof.Proof) < countFalse(multiproof.ProofFlags) {
-		panic(errors.New("Invalid multiproof format"))
+		return nil, throwError("Invalid multiproof format")
 	}
 
 	if len(multiproof.Leaves)+len(multiproof.Proof) != len(multiproof.ProofFlags)+1 {
-		panic(errors.New("Provided leaves and multiproof are not compatible"))
+		return nil, throwError("Provided leaves and multiproof are not compatible")
 	}
 
 	stack := make([]Bytes, len(multiproof.Leaves))
@@ -257,42 +260,41 @@ func ProcessMultiProof(multiproof MultiProof) Bytes {
 	copy(proof, multiproof.Proof)
 
 	for _, flag := range multiproof.ProofFlags {
-		a := stack[0]
-		if len(stack) > 1 {
-			stack = stack[1:] // consume from the stack
-		} else {
-			stack = make([]Bytes, 0)
+		if len(stack) == 0 {
+			return nil, throwError("Invalid multiproof format")
 		}
+		a := stack[0]
+		stack = stack[1:]
+
 		var b Bytes
 		if flag {
-			b = stack[0]
-			if len(stack) > 1 {
-				stack = stack[1:] // consume from the stack
-			} else {
-				stack = make([]Bytes, 0)
+			if len(stack) == 0 {
+				return nil, throwError("Invalid multiproof format")
 			}
+			b = stack[0]
+			stack = stack[1:]
 		} else {
-			b = proof[0]
-			if len(proof) > 1 {
-				proof = proof[1:] // consume from the stack
-			} else {
-				proof = make([]Bytes, 0)
+			if len(proof) == 0 {
+				return nil, throwError("Invalid multiproof format")
 			}
+			b = proof[0]
+			proof = proof[1:]
 		}
 		stack = append(stack, hashPair(a, b))
 	}
-	var result Bytes
+
 	if len(stack) > 0 {
-		result = stack[len(stack)-1]
-	} else if len(proof) > 0 {
-		result = proof[0]
+		return stack[len(stack)-1], nil
 	}
-	return result
+	if len(proof) > 0 {
+		return proof[0], nil
+	}
+	return nil, throwError("Invalid multiproof format")
 }
 
 func isValidMerkleTree(tree []Bytes) bool {
 	for i, node := range tree {
-		if !isValidMerkleNode(node) {
+		if !isValidMerkleNode(node, 32) {
 			return false
 		}
 
@@ -424,6 +426,5 @@ func GenerateMultiProof(tree []Bytes, tokenIds []*big.Int) (MultiProof, error) {
 		}
 		indices[i] = index
 	}
-	multiproof := GetMultiProof(tree, indices)
-	return multiproof, nil
+	return GetMultiProof(tree, indices)
 }
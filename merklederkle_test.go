@@ -38,13 +38,13 @@ func TestMerkleTree_Root(t *testing.T) {
 	fmt.Println(root)
 	fmt.Println(hex.EncodeToString(root))
 
-	multiProof := GetMultiProof(tree, []int{index + 1})
+	multiProof, _ := GetMultiProof(tree, []int{index + 1})
 	fmt.Println(multiProof)
 	if hex.EncodeToString(multiProof.Leaves[0]) != "2122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f40" {
 		t.Errorf("leaf is not correct, %s vs actual %s", hex.EncodeToString(multiProof.Leaves[0]), "2122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f40")
 	}
 
-	multiRoot := ProcessMultiProof(multiProof)
+	multiRoot, _ := ProcessMultiProof(multiProof)
 	fmt.Println(multiRoot)
 
 	valid := isValidMerkleTree(tree)
@@ -0,0 +1,208 @@
+package merklederkle
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NonMembershipProof exhibits the two leaves adjacent to a target in sorted
+// order - L < target < R - along with membership proofs against a
+// position-binding tree (see MakeNonMembershipTree), so a verifier can
+// conclude target is absent from the tree. Either side may be absent (nil
+// leaf, index -1) when target falls outside the tree's leaf range entirely;
+// at least one side must be present. This is analogous to the exclusion
+// proofs used by ICS23/IAVL trees in the Cosmos ecosystem.
+//
+// Left/Right carry the original (uncommitted) leaf values. LeftIndex/
+// RightIndex are not trusted caller metadata: VerifyNonMembership recomputes
+// the committed leaf for the claimed index and checks it against
+// LeftProof/RightProof, so a forged index does not combine to the real root.
+type NonMembershipProof struct {
+	TreeSize int
+
+	Left      Bytes
+	LeftIndex int
+	LeftProof []Bytes
+
+	Right      Bytes
+	RightIndex int
+	RightProof []Bytes
+}
+
+func numLeavesForSize(size int) int {
+	return (size + 1) / 2
+}
+
+// sortedIndexLeaf commits a leaf's ascending sort rank into its hash before
+// it enters the tree, so a membership proof against the resulting root also
+// authenticates the leaf's position. This is required for
+// GetNonMembershipProof/VerifyNonMembership's adjacency check to be sound:
+// the library's pair hash is commutative (shared with MakeMerkleTree/
+// StandardTree for OpenZeppelin compatibility), so an ordinary membership
+// proof binds a leaf to the root but not to any index on its own.
+func sortedIndexLeaf(rank int, leaf Bytes) Bytes {
+	rankBytes := common.LeftPadBytes(big.NewInt(int64(rank)).Bytes(), 32)
+	return keccak256(rankBytes, leaf)
+}
+
+// MakeNonMembershipTree sorts leaves ascending and builds a Merkle tree over
+// their sortedIndexLeaf commitments. The resulting root differs from
+// MakeMerkleTree's over the same leaves; use it (not MakeMerkleTree) when
+// non-membership proofs will be required.
+func MakeNonMembershipTree(leaves []Bytes) ([]Bytes, error) {
+	if len(leaves) == 0 {
+		return nil, throwError("Expected non-zero number of leaves")
+	}
+	for _, leaf := range leaves {
+		if err := checkValidMerkleNode(leaf); err != nil {
+			return nil, err
+		}
+	}
+
+	sorted := make([]Bytes, len(leaves))
+	copy(sorted, leaves)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+
+	tree := make([]Bytes, 2*len(sorted)-1)
+	for i, leaf := range sorted {
+		tree[len(tree)-1-i] = sortedIndexLeaf(i, leaf)
+	}
+	for i := len(tree) - 1 - len(sorted); i >= 0; i-- {
+		tree[i] = hashPair(tree[leftChildIndex(i)], tree[rightChildIndex(i)])
+	}
+	return tree, nil
+}
+
+// GetNonMembershipProof proves that target is not among leaves by sorting
+// leaves, building a position-binding tree over them (MakeNonMembershipTree),
+// and locating target's two sorted-order neighbors (or the single boundary
+// leaf, if target falls before the smallest or after the largest leaf). It
+// returns the tree alongside the proof so the caller can distribute its
+// root (tree[0]).
+func GetNonMembershipProof(leaves []Bytes, target Bytes) ([]Bytes, NonMembershipProof, error) {
+	if err := checkValidMerkleNode(target); err != nil {
+		return nil, NonMembershipProof{}, err
+	}
+
+	sorted := make([]Bytes, len(leaves))
+	copy(sorted, leaves)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+
+	n := len(sorted)
+	lo, hi := 0, n
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if bytes.Compare(sorted[mid], target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < n && equalsBytes(sorted[lo], target) {
+		return nil, NonMembershipProof{}, throwError("target is a member of the tree")
+	}
+
+	tree, err := MakeNonMembershipTree(leaves)
+	if err != nil {
+		return nil, NonMembershipProof{}, err
+	}
+
+	proof := NonMembershipProof{TreeSize: len(tree), LeftIndex: -1, RightIndex: -1}
+	if lo > 0 {
+		rank := lo - 1
+		index := len(tree) - 1 - rank
+		leftProof, err := GetProof(tree, index)
+		if err != nil {
+			return nil, NonMembershipProof{}, err
+		}
+		proof.Left, proof.LeftIndex, proof.LeftProof = sorted[rank], index, leftProof
+	}
+	if lo < n {
+		rank := lo
+		index := len(tree) - 1 - rank
+		rightProof, err := GetProof(tree, index)
+		if err != nil {
+			return nil, NonMembershipProof{}, err
+		}
+		proof.Right, proof.RightIndex, proof.RightProof = sorted[rank], index, rightProof
+	}
+	return tree, proof, nil
+}
+
+// VerifyNonMembership checks a NonMembershipProof against root: both
+// supplied leaves must recombine (once their claimed index is folded back
+// into the commitment sortedIndexLeaf expects) to root, must bracket
+// target, and - when both are present - must be adjacent leaves (their
+// tree indices differ by exactly 1); a missing side is only valid at the
+// corresponding boundary of the leaf range.
+func VerifyNonMembership(root Bytes, target Bytes, proof NonMembershipProof) (bool, error) {
+	if err := checkValidMerkleNode(target); err != nil {
+		return false, err
+	}
+	if proof.Left == nil && proof.Right == nil {
+		return false, throwError("Non-membership proof must include at least one boundary leaf")
+	}
+
+	n := numLeavesForSize(proof.TreeSize)
+	leafStart := proof.TreeSize - n
+
+	if proof.Left != nil {
+		if proof.LeftIndex < leafStart || proof.LeftIndex >= proof.TreeSize {
+			return false, throwError("left leaf index out of range")
+		}
+		if bytes.Compare(proof.Left, target) >= 0 {
+			return false, nil
+		}
+		rank := proof.TreeSize - 1 - proof.LeftIndex
+		computed, err := ProcessProof(sortedIndexLeaf(rank, proof.Left), proof.LeftProof)
+		if err != nil {
+			return false, err
+		}
+		if !equalsBytes(computed, root) {
+			return false, nil
+		}
+	} else if proof.RightIndex != proof.TreeSize-1 {
+		return false, throwError("a missing left leaf is only valid at the lower boundary")
+	}
+
+	if proof.Right != nil {
+		if proof.RightIndex < leafStart || proof.RightIndex >= proof.TreeSize {
+			return false, throwError("right leaf index out of range")
+		}
+		if bytes.Compare(proof.Right, target) <= 0 {
+			return false, nil
+		}
+		rank := proof.TreeSize - 1 - proof.RightIndex
+		computed, err := ProcessProof(sortedIndexLeaf(rank, proof.Right), proof.RightProof)
+		if err != nil {
+			return false, err
+		}
+		if !equalsBytes(computed, root) {
+			return false, nil
+		}
+	} else if proof.LeftIndex != leafStart {
+		return false, throwError("a missing right leaf is only valid at the upper boundary")
+	}
+
+	if proof.Left != nil && proof.Right != nil && proof.RightIndex != proof.LeftIndex-1 {
+		return false, throwError("left and right leaves are not adjacent")
+	}
+
+	return true, nil
+}
+
+// GenerateNonMembershipProof mirrors GenerateMerkleProof for the token-id
+// based API, proving tokenId's hash is absent from tokenIds. It builds its
+// own position-binding tree (MakeNonMembershipTree) rather than reusing a
+// tree from GenerateMerkleTree, since that tree's proofs don't authenticate
+// leaf position.
+func GenerateNonMembershipProof(tokenIds []*big.Int, tokenId *big.Int) ([]Bytes, NonMembershipProof, error) {
+	leaves := make([]Bytes, len(tokenIds))
+	for i, id := range tokenIds {
+		leaves[i] = HashFn(id)
+	}
+	return GetNonMembershipProof(leaves, HashFn(tokenId))
+}
@@ -0,0 +1,116 @@
+package merklederkle
+
+import (
+	"math/big"
+	"testing"
+)
+
+func leafWithFirstByte(b byte) Bytes {
+	leaf := make(Bytes, 32)
+	leaf[0] = b
+	return leaf
+}
+
+func TestNonMembershipProof_InteriorGap(t *testing.T) {
+	leaves := []Bytes{leafWithFirstByte(0x10), leafWithFirstByte(0x20), leafWithFirstByte(0x30)}
+
+	target := leafWithFirstByte(0x18)
+	tree, proof, err := GetNonMembershipProof(leaves, target)
+	if err != nil {
+		t.Fatalf("GetNonMembershipProof returned error: %v", err)
+	}
+	if proof.Left == nil || proof.Right == nil {
+		t.Fatal("expected both neighbors for a target missing between two leaves")
+	}
+
+	ok, err := VerifyNonMembership(tree[0], target, proof)
+	if err != nil {
+		t.Fatalf("VerifyNonMembership returned error: %v", err)
+	}
+	if !ok {
+		t.Error("expected non-membership proof to verify")
+	}
+
+	if ok, _ := VerifyNonMembership(tree[0], leafWithFirstByte(0x20), proof); ok {
+		t.Error("expected a proof for a different target to fail verification")
+	}
+}
+
+func TestNonMembershipProof_RejectsMember(t *testing.T) {
+	tokenIds := []*big.Int{big.NewInt(10), big.NewInt(20), big.NewInt(30)}
+
+	if _, _, err := GenerateNonMembershipProof(tokenIds, big.NewInt(20)); err == nil {
+		t.Error("expected an error when proving non-membership of an actual member")
+	}
+}
+
+func TestNonMembershipProof_Boundary(t *testing.T) {
+	tokenIds := []*big.Int{big.NewInt(10), big.NewInt(20), big.NewInt(30)}
+	leaves := make([]Bytes, len(tokenIds))
+	for i, id := range tokenIds {
+		leaves[i] = HashFn(id)
+	}
+
+	// The all-zero 32-byte value sorts below any real keccak256 hash.
+	target := Bytes(make([]byte, 32))
+	tree, proof, err := GetNonMembershipProof(leaves, target)
+	if err != nil {
+		t.Fatalf("GetNonMembershipProof returned error: %v", err)
+	}
+	if proof.Left != nil {
+		t.Error("expected no left neighbor for a target below the smallest leaf")
+	}
+
+	ok, err := VerifyNonMembership(tree[0], target, proof)
+	if err != nil {
+		t.Fatalf("VerifyNonMembership returned error: %v", err)
+	}
+	if !ok {
+		t.Error("expected boundary non-membership proof to verify")
+	}
+}
+
+// TestNonMembershipProof_ForgedIndicesRejected reproduces the reviewer's
+// attack: take two honest membership proofs for real, non-adjacent members
+// and relabel their LeftIndex/RightIndex to claim fake adjacency around an
+// actual member. Since the index is folded into the leaf commitment and
+// re-derived during verification (not trusted as proof metadata), the
+// forged proof must fail instead of validating the member as absent.
+func TestNonMembershipProof_ForgedIndicesRejected(t *testing.T) {
+	leaves := []Bytes{leafWithFirstByte(0x10), leafWithFirstByte(0x20), leafWithFirstByte(0x30), leafWithFirstByte(0x40)}
+	tree, err := MakeNonMembershipTree(leaves)
+	if err != nil {
+		t.Fatalf("MakeNonMembershipTree returned error: %v", err)
+	}
+	root := tree[0]
+
+	// Sorted ranks: 0x10=0, 0x20=1, 0x30=2, 0x40=3. Leaf array indices are
+	// len(tree)-1-rank, so rank 0 (0x10) sits at index 6 and rank 3 (0x40)
+	// sits at index 3 in this 7-node tree.
+	l1Proof, err := GetProof(tree, 6)
+	if err != nil {
+		t.Fatalf("GetProof(L1) returned error: %v", err)
+	}
+	l3Proof, err := GetProof(tree, 3)
+	if err != nil {
+		t.Fatalf("GetProof(L3) returned error: %v", err)
+	}
+
+	// Attacker's construction: reuse L1's real proof but claim it
+	// sits at the index adjacent to L3's real index, and vice versa.
+	attack := NonMembershipProof{
+		TreeSize:   len(tree),
+		Left:       leafWithFirstByte(0x10),
+		LeftIndex:  4, // fabricated: L1's real index is 6, not 4
+		LeftProof:  l1Proof,
+		Right:      leafWithFirstByte(0x40),
+		RightIndex: 3,
+		RightProof: l3Proof,
+	}
+
+	target := leafWithFirstByte(0x30) // an actual member
+	ok, _ := VerifyNonMembership(root, target, attack)
+	if ok {
+		t.Fatal("forged indices let a real member verify as absent")
+	}
+}
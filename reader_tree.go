@@ -0,0 +1,90 @@
+package merklederkle
+
+import "io"
+
+// BuildReaderTree hashes r in fixed-size segments into leaves and builds a
+// tree over them without requiring the caller to materialize every leaf
+// up front, for committing to file/data blobs rather than token-id lists.
+//
+// Unlike MakeMerkleTree/NewTree, leaves are NOT sorted before pairing: the
+// tree preserves segment read order so that a segment's index (0-based, in
+// read order) can be used directly as its proofIndex. The final segment is
+// hashed as-is if shorter than segmentSize - it is not zero-padded - and
+// VerifyReaderProof must be given that same short segment to verify.
+func BuildReaderTree(r io.Reader, segmentSize int, hasher Hasher) (Bytes, []Bytes, int, error) {
+	if segmentSize <= 0 {
+		return nil, nil, 0, throwError("segmentSize must be positive")
+	}
+	if hasher == nil {
+		hasher = KeccakHasher{}
+	}
+
+	leaves := make([]Bytes, 0)
+	buf := make([]byte, segmentSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			segment := make([]byte, n)
+			copy(segment, buf[:n])
+			leaves = append(leaves, Bytes(hasher.Hash(segment)))
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	if len(leaves) == 0 {
+		return nil, nil, 0, throwError("Expected non-zero number of leaves")
+	}
+
+	tree := buildOrderedTree(leaves, hasher)
+	return tree[0], tree, len(leaves), nil
+}
+
+// BuildReaderProof re-derives the same tree BuildReaderTree would and
+// returns the leaf and proof for the segment at proofIndex (in read order).
+func BuildReaderProof(r io.Reader, segmentSize int, proofIndex uint64, hasher Hasher) (Bytes, []Bytes, error) {
+	_, tree, numLeaves, err := BuildReaderTree(r, segmentSize, hasher)
+	if err != nil {
+		return nil, nil, err
+	}
+	if proofIndex >= uint64(numLeaves) {
+		return nil, nil, throwError("proofIndex out of range")
+	}
+
+	treeIndex := len(tree) - 1 - int(proofIndex)
+	proof, err := GetProof(tree, treeIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tree[treeIndex], proof, nil
+}
+
+// VerifyReaderProof checks that hashing segment and combining it with proof
+// reconstructs root. segment must be passed exactly as it was read from the
+// original reader, including an un-padded short final segment.
+func VerifyReaderProof(root Bytes, segment []byte, proof []Bytes, hasher Hasher) (bool, error) {
+	if hasher == nil {
+		hasher = KeccakHasher{}
+	}
+	leaf := Bytes(hasher.Hash(segment))
+	computed, err := (&Tree{Hasher: hasher}).ProcessProof(leaf, proof)
+	if err != nil {
+		return false, err
+	}
+	return equalsBytes(computed, root), nil
+}
+
+func buildOrderedTree(leaves []Bytes, hasher Hasher) []Bytes {
+	tree := make([]Bytes, 2*len(leaves)-1)
+	for i, leaf := range leaves {
+		tree[len(tree)-1-i] = leaf
+	}
+	for i := len(tree) - 1 - len(leaves); i >= 0; i-- {
+		tree[i] = hashPairWith(hasher, tree[leftChildIndex(i)], tree[rightChildIndex(i)])
+	}
+	return tree
+}
@@ -0,0 +1,45 @@
+package merklederkle
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBuildReaderTree_RoundTrip(t *testing.T) {
+	data := []byte("the quick brown fox jumps over the lazy dog, twice over")
+
+	root, _, numLeaves, err := BuildReaderTree(bytes.NewReader(data), 8, nil)
+	if err != nil {
+		t.Fatalf("BuildReaderTree returned error: %v", err)
+	}
+	if numLeaves == 0 {
+		t.Fatal("expected at least one leaf")
+	}
+
+	for i := 0; i < numLeaves; i++ {
+		leaf, proof, err := BuildReaderProof(bytes.NewReader(data), 8, uint64(i), nil)
+		if err != nil {
+			t.Fatalf("BuildReaderProof(%d) returned error: %v", i, err)
+		}
+
+		start := i * 8
+		end := start + 8
+		if end > len(data) {
+			end = len(data)
+		}
+		segment := data[start:end]
+
+		expectedLeaf := KeccakHasher{}.Hash(segment)
+		if !equalsBytes(leaf, expectedLeaf) {
+			t.Errorf("segment %d: leaf did not match expected hash", i)
+		}
+
+		ok, err := VerifyReaderProof(root, segment, proof, nil)
+		if err != nil {
+			t.Fatalf("VerifyReaderProof(%d) returned error: %v", i, err)
+		}
+		if !ok {
+			t.Errorf("segment %d: proof did not verify against the root", i)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package merklederkle
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+/*
+Go implementation of OpenZeppelin's StandardMerkleTree
+(https://github.com/OpenZeppelin/merkle-tree), which ABI-encodes each leaf's
+values and hashes it twice before building the tree:
+
+	leaf = keccak256(keccak256(abi.encode(leafEncodings, values)))
+
+Double-hashing prevents second-preimage attacks where an internal tree node
+(also 32 bytes) could be presented as a leaf.
+*/
+
+// StandardLeaf pairs an original value with the index it ended up at in the
+// built tree, matching the `values` entries of OpenZeppelin's dump format.
+type StandardLeaf struct {
+	Value     []interface{}
+	TreeIndex int
+	leaf      Bytes
+}
+
+type StandardTree struct {
+	tree          []Bytes
+	values        []StandardLeaf
+	leafEncodings []string
+}
+
+func standardLeafHash(leafEncodings []string, value []interface{}) (Bytes, error) {
+	if len(leafEncodings) != len(value) {
+		return nil, throwError("Value and leafEncodings length mismatch")
+	}
+
+	args := make(abi.Arguments, len(leafEncodings))
+	for i, encoding := range leafEncodings {
+		t, err := abi.NewType(encoding, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = abi.Argument{Type: t}
+	}
+
+	encoded, err := args.Pack(value...)
+	if err != nil {
+		return nil, err
+	}
+
+	return keccak256(keccak256(encoded)), nil
+}
+
+// NewStandardTree builds a StandardTree from a list of Solidity-typed values,
+// one leafEncoding per value column (e.g. "address", "uint256", "bytes32").
+func NewStandardTree(values [][]interface{}, leafEncodings []string) (*StandardTree, error) {
+	if len(values) == 0 {
+		return nil, throwError("Could not generate merkle tree")
+	}
+
+	leaves := make([]StandardLeaf, len(values))
+	for i, value := range values {
+		leaf, err := standardLeafHash(leafEncodings, value)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = StandardLeaf{Value: value, leaf: leaf}
+	}
+
+	sort.Slice(leaves, func(i, j int) bool { return bytes.Compare(leaves[i].leaf, leaves[j].leaf) < 0 })
+
+	rawLeaves := make([]Bytes, len(leaves))
+	for i, l := range leaves {
+		rawLeaves[i] = l.leaf
+	}
+	tree := MakeMerkleTree(rawLeaves)
+
+	for i := range leaves {
+		leaves[i].TreeIndex = len(tree) - 1 - i
+	}
+
+	return &StandardTree{
+		tree:          tree,
+		values:        leaves,
+		leafEncodings: leafEncodings,
+	}, nil
+}
+
+func (t *StandardTree) Root() Bytes {
+	return t.tree[0]
+}
+
+func (t *StandardTree) indexOf(value []interface{}) (int, error) {
+	leaf, err := standardLeafHash(t.leafEncodings, value)
+	if err != nil {
+		return -1, err
+	}
+	for _, v := range t.values {
+		if equalsBytes(v.leaf, leaf) {
+			return v.TreeIndex, nil
+		}
+	}
+	return -1, throwError("Leaf not found in tree")
+}
+
+// GetProof returns the merkle proof for a value, identified by re-hashing it
+// with the tree's leafEncodings.
+func (t *StandardTree) GetProof(value []interface{}) ([]Bytes, error) {
+	index, err := t.indexOf(value)
+	if err != nil {
+		return nil, err
+	}
+	return GetProof(t.tree, index)
+}
+
+// GetMultiProof returns a combined proof for several values at once.
+func (t *StandardTree) GetMultiProof(values [][]interface{}) (MultiProof, error) {
+	indices := make([]int, len(values))
+	for i, value := range values {
+		index, err := t.indexOf(value)
+		if err != nil {
+			return MultiProof{}, err
+		}
+		indices[i] = index
+	}
+	return GetMultiProof(t.tree, indices)
+}
+
+// Verify checks that leaf combines with proof to the given root, the same
+// check a Solidity verifier using OpenZeppelin's MerkleProof.verify would do.
+func (t *StandardTree) Verify(root Bytes, leaf Bytes, proof []Bytes) (bool, error) {
+	computed, err := ProcessProof(leaf, proof)
+	if err != nil {
+		return false, err
+	}
+	return equalsBytes(computed, root), nil
+}
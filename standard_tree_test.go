@@ -0,0 +1,66 @@
+package merklederkle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestStandardTree_RootAndProof(t *testing.T) {
+	values := [][]interface{}{
+		{common.HexToAddress("0x1111111111111111111111111111111111111111"), big.NewInt(5)},
+		{common.HexToAddress("0x2222222222222222222222222222222222222222"), big.NewInt(10)},
+		{common.HexToAddress("0x3333333333333333333333333333333333333333"), big.NewInt(15)},
+	}
+	encodings := []string{"address", "uint256"}
+
+	tree, err := NewStandardTree(values, encodings)
+	if err != nil {
+		t.Fatalf("NewStandardTree returned error: %v", err)
+	}
+
+	proof, err := tree.GetProof(values[1])
+	if err != nil {
+		t.Fatalf("GetProof returned error: %v", err)
+	}
+
+	leaf, err := standardLeafHash(encodings, values[1])
+	if err != nil {
+		t.Fatalf("standardLeafHash returned error: %v", err)
+	}
+
+	ok, err := tree.Verify(tree.Root(), leaf, proof)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if !ok {
+		t.Error("expected proof to verify against the tree root")
+	}
+}
+
+func TestStandardTree_GetMultiProof(t *testing.T) {
+	values := [][]interface{}{
+		{common.HexToAddress("0x1111111111111111111111111111111111111111"), big.NewInt(5)},
+		{common.HexToAddress("0x2222222222222222222222222222222222222222"), big.NewInt(10)},
+	}
+	encodings := []string{"address", "uint256"}
+
+	tree, err := NewStandardTree(values, encodings)
+	if err != nil {
+		t.Fatalf("NewStandardTree returned error: %v", err)
+	}
+
+	multiProof, err := tree.GetMultiProof(values)
+	if err != nil {
+		t.Fatalf("GetMultiProof returned error: %v", err)
+	}
+
+	root, err := ProcessMultiProof(multiProof)
+	if err != nil {
+		t.Fatalf("ProcessMultiProof returned error: %v", err)
+	}
+	if !equalsBytes(root, tree.Root()) {
+		t.Error("multiproof did not reconstruct the tree root")
+	}
+}
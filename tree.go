@@ -0,0 +1,225 @@
+package merklederkle
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Tree is a Merkle tree parameterized over a pluggable Hasher. It mirrors
+// MakeMerkleTree/GetProof/ProcessProof/GetMultiProof/ProcessMultiProof but
+// threads the Hasher through instead of hardcoding Keccak-256, so it can
+// back non-EVM trees (SHA-256, Poseidon) as well.
+type Tree struct {
+	Hasher Hasher
+	Nodes  []Bytes
+}
+
+func hashPairWith(hasher Hasher, a Bytes, b Bytes) Bytes {
+	if bytes.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+	return hasher.Hash(concatBytes(a, b))
+}
+
+func checkValidMerkleNodeWith(hasher Hasher, node Bytes) error {
+	if !isValidMerkleNode(node, hasher.Size()) {
+		return throwError("Merkle tree nodes must be Bytes of the hasher's size")
+	}
+	return nil
+}
+
+// NewTree builds a Tree over leaves using hasher, sorting leaves before
+// pairing exactly as MakeMerkleTree does. A nil hasher defaults to KeccakHasher.
+func NewTree(leaves []Bytes, hasher Hasher) (*Tree, error) {
+	if hasher == nil {
+		hasher = KeccakHasher{}
+	}
+	for _, leaf := range leaves {
+		if err := checkValidMerkleNodeWith(hasher, leaf); err != nil {
+			return nil, err
+		}
+	}
+	if len(leaves) == 0 {
+		return nil, throwError("Expected non-zero number of leaves")
+	}
+
+	sort.Slice(leaves, func(i, j int) bool { return bytes.Compare(leaves[i], leaves[j]) < 0 })
+	nodes := make([]Bytes, 2*len(leaves)-1)
+	for i, leaf := range leaves {
+		nodes[len(nodes)-1-i] = leaf
+	}
+	for i := len(nodes) - 1 - len(leaves); i >= 0; i-- {
+		nodes[i] = hashPairWith(hasher, nodes[leftChildIndex(i)], nodes[rightChildIndex(i)])
+	}
+
+	return &Tree{Hasher: hasher, Nodes: nodes}, nil
+}
+
+func (t *Tree) Root() Bytes {
+	return t.Nodes[0]
+}
+
+func (t *Tree) checkLeafNode(i int) error {
+	if !isLeafNode(t.Nodes, i) {
+		return throwError("Index is not a leaf")
+	}
+	return nil
+}
+
+func (t *Tree) GetProof(index int) ([]Bytes, error) {
+	if err := t.checkLeafNode(index); err != nil {
+		return []Bytes{}, err
+	}
+
+	proof := make([]Bytes, 0)
+	for index > 0 {
+		proof = append(proof, t.Nodes[siblingIndex(index)])
+		index, _ = parentIndex(index)
+	}
+	return proof, nil
+}
+
+func (t *Tree) ProcessProof(leaf Bytes, proof []Bytes) (Bytes, error) {
+	if err := checkValidMerkleNodeWith(t.Hasher, leaf); err != nil {
+		return Bytes{}, err
+	}
+	for _, p := range proof {
+		if err := checkValidMerkleNodeWith(t.Hasher, p); err != nil {
+			return Bytes{}, err
+		}
+	}
+
+	result := leaf
+	for _, p := range proof {
+		result = hashPairWith(t.Hasher, result, p)
+	}
+	return result, nil
+}
+
+func (t *Tree) GetMultiProof(indices []int) (MultiProof, error) {
+	for _, i := range indices {
+		if err := t.checkLeafNode(i); err != nil {
+			return MultiProof{}, err
+		}
+	}
+	indices = sortIndicesDesc(indices)
+	if hasDuplicateIndex(indices) {
+		return MultiProof{}, throwError("Cannot prove duplicated index")
+	}
+
+	stack := make([]int, len(indices))
+	copy(stack, indices)
+	proof := make([]Bytes, 0)
+	proofFlags := make([]bool, 0)
+
+	for len(stack) > 0 && stack[0] > 0 {
+		j := stack[0]
+		if len(stack) > 1 {
+			stack = stack[1:]
+		} else {
+			stack = make([]int, 0)
+		}
+		s := siblingIndex(j)
+		p, _ := parentIndex(j)
+
+		if len(stack) > 0 && s == stack[0] {
+			proofFlags = append(proofFlags, true)
+			if len(stack) > 1 {
+				stack = stack[1:]
+			} else {
+				stack = make([]int, 0)
+			}
+		} else {
+			proofFlags = append(proofFlags, false)
+			proof = append(proof, t.Nodes[s])
+		}
+		stack = append(stack, p)
+	}
+
+	if len(indices) == 0 {
+		proof = append(proof, t.Nodes[0])
+	}
+
+	return MultiProof{
+		Leaves:     getIndicesValues(t.Nodes, indices),
+		Proof:      proof,
+		ProofFlags: proofFlags,
+	}, nil
+}
+
+func (t *Tree) ProcessMultiProof(multiproof MultiProof) (Bytes, error) {
+	for _, l := range multiproof.Leaves {
+		if err := checkValidMerkleNodeWith(t.Hasher, l); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range multiproof.Proof {
+		if err := checkValidMerkleNodeWith(t.Hasher, p); err != nil {
+			return nil, err
+		}
+	}
+	if len(multiproof.Proof) < countFalse(multiproof.ProofFlags) {
+		return nil, throwError("Invalid multiproof format")
+	}
+	if len(multiproof.Leaves)+len(multiproof.Proof) != len(multiproof.ProofFlags)+1 {
+		return nil, throwError("Provided leaves and multiproof are not compatible")
+	}
+
+	stack := make([]Bytes, len(multiproof.Leaves))
+	copy(stack, multiproof.Leaves)
+	proof := make([]Bytes, len(multiproof.Proof))
+	copy(proof, multiproof.Proof)
+
+	for _, flag := range multiproof.ProofFlags {
+		if len(stack) == 0 {
+			return nil, throwError("Invalid multiproof format")
+		}
+		a := stack[0]
+		stack = stack[1:]
+
+		var b Bytes
+		if flag {
+			if len(stack) == 0 {
+				return nil, throwError("Invalid multiproof format")
+			}
+			b = stack[0]
+			stack = stack[1:]
+		} else {
+			if len(proof) == 0 {
+				return nil, throwError("Invalid multiproof format")
+			}
+			b = proof[0]
+			proof = proof[1:]
+		}
+		stack = append(stack, hashPairWith(t.Hasher, a, b))
+	}
+
+	if len(stack) > 0 {
+		return stack[len(stack)-1], nil
+	}
+	if len(proof) > 0 {
+		return proof[0], nil
+	}
+	return nil, throwError("Invalid multiproof format")
+}
+
+func (t *Tree) IsValid() bool {
+	for i, node := range t.Nodes {
+		if !isValidMerkleNode(node, t.Hasher.Size()) {
+			return false
+		}
+
+		l := leftChildIndex(i)
+		r := rightChildIndex(i)
+
+		if r >= len(t.Nodes) {
+			if l < len(t.Nodes) {
+				return false
+			}
+		} else if !equalsBytes(node, hashPairWith(t.Hasher, t.Nodes[l], t.Nodes[r])) {
+			return false
+		}
+	}
+
+	return len(t.Nodes) > 0
+}
@@ -0,0 +1,49 @@
+package merklederkle
+
+import (
+	"testing"
+)
+
+func TestTree_Sha256Hasher(t *testing.T) {
+	leaves := []Bytes{
+		make(Bytes, 32), make(Bytes, 32), make(Bytes, 32),
+	}
+	leaves[0][0] = 0x01
+	leaves[1][0] = 0x02
+	leaves[2][0] = 0x03
+
+	tree, err := NewTree(leaves, Sha256Hasher{})
+	if err != nil {
+		t.Fatalf("NewTree returned error: %v", err)
+	}
+	if !tree.IsValid() {
+		t.Error("expected tree to be valid")
+	}
+
+	proof, err := tree.GetProof(len(tree.Nodes) - 1)
+	if err != nil {
+		t.Fatalf("GetProof returned error: %v", err)
+	}
+
+	root, err := tree.ProcessProof(tree.Nodes[len(tree.Nodes)-1], proof)
+	if err != nil {
+		t.Fatalf("ProcessProof returned error: %v", err)
+	}
+	if !equalsBytes(root, tree.Root()) {
+		t.Error("proof did not reconstruct the tree root")
+	}
+}
+
+func TestTree_DefaultsToKeccak(t *testing.T) {
+	leaves := []Bytes{make(Bytes, 32), make(Bytes, 32)}
+	leaves[0][0] = 0x01
+	leaves[1][0] = 0x02
+
+	tree, err := NewTree(leaves, nil)
+	if err != nil {
+		t.Fatalf("NewTree returned error: %v", err)
+	}
+	if _, ok := tree.Hasher.(KeccakHasher); !ok {
+		t.Error("expected nil hasher to default to KeccakHasher")
+	}
+}
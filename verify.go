@@ -0,0 +1,22 @@
+package merklederkle
+
+// VerifyProof checks that leaf combines with proof to the given root,
+// matching Solidity's MerkleProof.verify so callers don't need to call
+// ProcessProof and compare bytes themselves.
+func VerifyProof(root Bytes, leaf Bytes, proof []Bytes) bool {
+	computed, err := ProcessProof(leaf, proof)
+	if err != nil {
+		return false
+	}
+	return equalsBytes(computed, root)
+}
+
+// VerifyMultiProof checks that multiproof combines to the given root,
+// matching Solidity's MerkleProof.multiProofVerify.
+func VerifyMultiProof(root Bytes, multiproof MultiProof) bool {
+	computed, err := ProcessMultiProof(multiproof)
+	if err != nil {
+		return false
+	}
+	return equalsBytes(computed, root)
+}
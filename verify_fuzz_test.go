@@ -0,0 +1,95 @@
+package merklederkle
+
+import "testing"
+
+// FuzzProcessMultiProof feeds ProcessMultiProof arbitrary leaf/proof counts
+// and ProofFlags, confirming it always returns an error instead of
+// panicking on malformed input - the kind of thing an untrusted RPC
+// endpoint could hand back.
+func FuzzProcessMultiProof(f *testing.F) {
+	f.Add(1, 1, 1, false)
+	f.Add(0, 0, 0, true)
+	f.Add(3, 2, 7, true)
+	f.Add(0, 1, 0, false)
+
+	f.Fuzz(func(t *testing.T, numLeaves, numProof, flagBits int, flagExtra bool) {
+		if numLeaves < 0 || numLeaves > 32 || numProof < 0 || numProof > 32 {
+			return
+		}
+
+		leaves := make([]Bytes, numLeaves)
+		for i := range leaves {
+			leaves[i] = make(Bytes, 32)
+			leaves[i][0] = byte(i)
+		}
+		proof := make([]Bytes, numProof)
+		for i := range proof {
+			proof[i] = make(Bytes, 32)
+			proof[i][0] = byte(i + 100)
+		}
+
+		numFlags := numLeaves + numProof - 1
+		if numFlags < 0 {
+			numFlags = 0
+		}
+		flags := make([]bool, numFlags)
+		for i := range flags {
+			flags[i] = (flagBits>>uint(i%32))&1 == 1
+		}
+		if numFlags > 0 {
+			flags[numFlags-1] = flagExtra
+		}
+
+		multiproof := MultiProof{Leaves: leaves, Proof: proof, ProofFlags: flags}
+
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("ProcessMultiProof panicked on %+v: %v", multiproof, r)
+			}
+		}()
+		_, _ = ProcessMultiProof(multiproof)
+		_ = VerifyMultiProof(make(Bytes, 32), multiproof)
+
+		tree := &Tree{Hasher: KeccakHasher{}}
+		_, _ = tree.ProcessMultiProof(multiproof)
+	})
+}
+
+// FuzzGetMultiProof confirms GetMultiProof never panics on leaf counts from
+// 1 to 32 and on selections of distinct leaf indices of varying size.
+func FuzzGetMultiProof(f *testing.F) {
+	f.Add(3, 1)
+	f.Add(5, 10)
+	f.Add(1, 0)
+
+	f.Fuzz(func(t *testing.T, numLeaves, indexSeed int) {
+		if numLeaves <= 0 || numLeaves > 32 {
+			return
+		}
+
+		leaves := make([]Bytes, numLeaves)
+		for i := range leaves {
+			leaves[i] = make(Bytes, 32)
+			leaves[i][0] = byte(i)
+		}
+		tree := MakeMerkleTree(leaves)
+
+		seed := indexSeed % numLeaves
+		if seed < 0 {
+			seed += numLeaves
+		}
+		numIndices := seed + 1
+
+		indices := make([]int, 0, numIndices)
+		for i := 0; i < numIndices; i++ {
+			indices = append(indices, len(tree)-1-((seed+i)%numLeaves))
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("GetMultiProof panicked on leaves=%d indices=%v: %v", numLeaves, indices, r)
+			}
+		}()
+		_, _ = GetMultiProof(tree, indices)
+	})
+}
@@ -0,0 +1,44 @@
+package merklederkle
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVerifyProof(t *testing.T) {
+	tokenIds := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	tree := GenerateMerkleTree(tokenIds)
+
+	proof, err := GenerateMerkleProof(tree, big.NewInt(2))
+	if err != nil {
+		t.Fatalf("GenerateMerkleProof returned error: %v", err)
+	}
+
+	if !VerifyProof(tree[0], HashFn(big.NewInt(2)), proof) {
+		t.Error("expected VerifyProof to succeed for a valid proof")
+	}
+	if VerifyProof(tree[0], HashFn(big.NewInt(99)), proof) {
+		t.Error("expected VerifyProof to fail for a mismatched leaf")
+	}
+}
+
+func TestVerifyMultiProof(t *testing.T) {
+	tokenIds := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+	tree := GenerateMerkleTree(tokenIds)
+
+	multiProof, err := GenerateMultiProof(tree, []*big.Int{big.NewInt(2), big.NewInt(4)})
+	if err != nil {
+		t.Fatalf("GenerateMultiProof returned error: %v", err)
+	}
+
+	if !VerifyMultiProof(tree[0], multiProof) {
+		t.Error("expected VerifyMultiProof to succeed for a valid multiproof")
+	}
+
+	tampered := multiProof
+	tampered.Leaves = append([]Bytes{}, multiProof.Leaves...)
+	tampered.Leaves[0] = HashFn(big.NewInt(999))
+	if VerifyMultiProof(tree[0], tampered) {
+		t.Error("expected VerifyMultiProof to fail for a tampered multiproof")
+	}
+}